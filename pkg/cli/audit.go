@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// AuditOptions configures a single audit collection run.
+type AuditOptions struct {
+	// RunID is the GitHub Actions run id to audit.
+	RunID int64
+	// LogsDir is the root directory under which per-run logs are cached,
+	// typically ".github/aw/logs".
+	LogsDir string
+	// Sinks are declarative filter+destination routes evaluated against the
+	// collected AuditData once it is built. See audit_sinks.go.
+	Sinks []AuditSink
+}
+
+// AuditOverview summarizes the workflow run itself.
+type AuditOverview struct {
+	RunID        int64  `json:"run_id"`
+	WorkflowName string `json:"workflow_name"`
+	Status       string `json:"status"`
+	Conclusion   string `json:"conclusion"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	StartedAt    string `json:"started_at,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+	Duration     string `json:"duration,omitempty"`
+	Event        string `json:"event,omitempty"`
+	Branch       string `json:"branch,omitempty"`
+	URL          string `json:"url,omitempty"`
+	LogsPath     string `json:"logs_path,omitempty"`
+}
+
+// AuditMetrics summarizes token usage and cost for the run.
+type AuditMetrics struct {
+	TokenUsage    int64  `json:"token_usage"`
+	EstimatedCost string `json:"estimated_cost,omitempty"`
+	Turns         int    `json:"turns,omitempty"`
+	ErrorCount    int    `json:"error_count"`
+	WarningCount  int    `json:"warning_count"`
+}
+
+// AuditJob is a single job within the audited workflow run.
+type AuditJob struct {
+	Name       string `json:"name" table:"name"`
+	Status     string `json:"status" table:"status"`
+	Conclusion string `json:"conclusion,omitempty" table:"conclusion"`
+	Duration   string `json:"duration,omitempty" table:"duration"`
+}
+
+// DownloadedFile describes an artifact downloaded while collecting the audit.
+type DownloadedFile struct {
+	Path          string `json:"path" table:"path"`
+	Size          int64  `json:"size" table:"size"`
+	SizeFormatted string `json:"size_formatted,omitempty" table:"size_formatted"`
+	Description   string `json:"description,omitempty" table:"description"`
+	IsDirectory   bool   `json:"is_directory" table:"is_directory"`
+}
+
+// MissingTool records a tool the agent requested that was not configured.
+type MissingTool struct {
+	Tool   string `json:"tool" table:"tool"`
+	Reason string `json:"reason" table:"reason"`
+}
+
+// AuditWarning is a single warning surfaced during audit collection.
+type AuditWarning struct {
+	File    string `json:"file,omitempty" table:"file"`
+	Line    int    `json:"line,omitempty" table:"line"`
+	Type    string `json:"type,omitempty" table:"type"`
+	Message string `json:"message" table:"message"`
+}
+
+// ToolUsage aggregates call statistics for a single tool across the run.
+type ToolUsage struct {
+	Name          string `json:"name" table:"name"`
+	CallCount     int    `json:"call_count" table:"call_count"`
+	MaxInputSize  int64  `json:"max_input_size,omitempty" table:"max_input_size"`
+	MaxOutputSize int64  `json:"max_output_size,omitempty" table:"max_output_size"`
+	MaxDuration   string `json:"max_duration,omitempty" table:"max_duration"`
+}
+
+// AuditData is the full result of an audit collection, and is the shape
+// serialized to JSON for `gh aw audit` and consumed by ApplyJqFilter.
+type AuditData struct {
+	Overview         AuditOverview    `json:"overview"`
+	Metrics          AuditMetrics     `json:"metrics"`
+	Jobs             []AuditJob       `json:"jobs"`
+	DownloadedFiles  []DownloadedFile `json:"downloaded_files"`
+	MissingTools     []MissingTool    `json:"missing_tools"`
+	MCPFailures      []string         `json:"mcp_failures"`
+	Errors           []string         `json:"errors"`
+	Warnings         []AuditWarning   `json:"warnings"`
+	ToolUsage        []ToolUsage      `json:"tool_usage"`
+	FirewallAnalysis any              `json:"firewall_analysis"`
+}
+
+// runDir returns the cache directory for a given run id, e.g.
+// ".github/aw/logs/run-21784234145".
+func runDir(logsDir string, runID int64) string {
+	return filepath.Join(logsDir, fmt.Sprintf("run-%d", runID))
+}
+
+// AuditRun collects audit data for a single workflow run. Collection reads
+// from the on-disk log cache populated by earlier `gh aw logs` downloads;
+// callers are expected to have already fetched the run's artifacts into
+// opts.LogsDir before calling AuditRun.
+func AuditRun(ctx context.Context, opts AuditOptions) (*AuditData, error) {
+	if opts.RunID == 0 {
+		return nil, fmt.Errorf("audit: run id is required")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	dir := runDir(opts.LogsDir, opts.RunID)
+	dataPath := filepath.Join(dir, "audit.json")
+
+	raw, err := os.ReadFile(dataPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("audit: no cached logs found for run %d in %s (run `gh aw logs` first)", opts.RunID, dir)
+		}
+		return nil, fmt.Errorf("audit: failed to read cached audit data: %w", err)
+	}
+
+	var data AuditData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("audit: failed to parse cached audit data: %w", err)
+	}
+
+	if data.Overview.RunID == 0 {
+		data.Overview.RunID = opts.RunID
+	}
+	if data.Overview.LogsPath == "" {
+		data.Overview.LogsPath = dir
+	}
+
+	if len(opts.Sinks) > 0 {
+		if err := RouteToSinks(ctx, &data, opts.Sinks, opts.LogsDir); err != nil {
+			return &data, fmt.Errorf("audit: %w", err)
+		}
+	}
+
+	return &data, nil
+}
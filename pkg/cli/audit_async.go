@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jobRetention is how long a finished job's record is kept in the registry
+// before it expires and is no longer queryable.
+const jobRetention = 60 * time.Second
+
+// now is a seam over time.Now so tests can control job timestamps; production
+// code always uses the real clock.
+var now = time.Now
+
+// JobStage describes the current phase of an in-flight audit job.
+type JobStage string
+
+const (
+	JobStagePending   JobStage = "pending"
+	JobStageFetching  JobStage = "fetching"
+	JobStageAnalyzing JobStage = "analyzing"
+	JobStageDone      JobStage = "done"
+)
+
+// Job is a single tracked asynchronous audit run.
+type Job struct {
+	ID         string     `json:"id"`
+	RunID      int64      `json:"run_id,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Progress   float64    `json:"progress"`
+	Stage      JobStage   `json:"stage"`
+	Error      string     `json:"error,omitempty"`
+	Result     *AuditData `json:"result,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+// snapshot returns a copy of the job safe to hand to callers outside the
+// registry's lock.
+func (j *Job) snapshot() *Job {
+	cp := *j
+	cp.cancel = nil
+	return &cp
+}
+
+// JobRegistry tracks in-flight and recently-finished audit jobs, both
+// in-memory and mirrored to disk under ".github/aw/jobs" so `gh aw job
+// status` works across separate CLI invocations.
+type JobRegistry struct {
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	jobsDir  string
+	sleepFor func(d time.Duration) <-chan time.Time
+}
+
+// NewJobRegistry creates a registry that persists job records under
+// filepath.Join(jobsDir, "<id>.json").
+func NewJobRegistry(jobsDir string) *JobRegistry {
+	return &JobRegistry{
+		jobs:    make(map[string]*Job),
+		jobsDir: jobsDir,
+		sleepFor: func(d time.Duration) <-chan time.Time {
+			return time.After(d)
+		},
+	}
+}
+
+// Start registers a new job for runID and begins running fn in a goroutine,
+// updating progress via the provided report callback. It returns immediately
+// with the new job's id.
+func (r *JobRegistry) Start(ctx context.Context, runID int64, fn func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error)) string {
+	id := uuid.NewString()
+	jobCtx, cancel := context.WithCancel(ctx)
+
+	job := &Job{
+		ID:        id,
+		RunID:     runID,
+		StartedAt: now(),
+		Stage:     JobStagePending,
+		cancel:    cancel,
+	}
+
+	r.mu.Lock()
+	r.jobs[id] = job
+	snap := job.snapshot()
+	r.mu.Unlock()
+	r.persist(snap)
+
+	go func() {
+		report := func(stage JobStage, progress float64) {
+			r.mu.Lock()
+			job.Stage = stage
+			job.Progress = progress
+			snap := job.snapshot()
+			r.mu.Unlock()
+			r.persist(snap)
+		}
+
+		result, err := fn(jobCtx, report)
+
+		r.mu.Lock()
+		finishedAt := now()
+		job.FinishedAt = &finishedAt
+		job.Progress = 1
+		job.Stage = JobStageDone
+		if err != nil {
+			job.Error = err.Error()
+		} else {
+			job.Result = result
+		}
+		snap := job.snapshot()
+		r.mu.Unlock()
+		r.persist(snap)
+
+		go r.expireAfterRetention(id)
+	}()
+
+	return id
+}
+
+// expireAfterRetention removes a finished job from the registry once the
+// retention window has elapsed.
+func (r *JobRegistry) expireAfterRetention(id string) {
+	<-r.sleepFor(jobRetention)
+	r.mu.Lock()
+	delete(r.jobs, id)
+	r.mu.Unlock()
+	if r.jobsDir != "" {
+		_ = os.Remove(r.jobPath(id))
+	}
+}
+
+// Status returns the current snapshot of a job, or an error if the job is
+// unknown or has already expired.
+func (r *JobRegistry) Status(id string) (*Job, error) {
+	r.mu.Lock()
+	job, ok := r.jobs[id]
+	var snap *Job
+	if ok {
+		snap = job.snapshot()
+	}
+	r.mu.Unlock()
+	if ok {
+		return snap, nil
+	}
+
+	if r.jobsDir != "" {
+		if job, err := r.loadFromDisk(id); err == nil {
+			return job, nil
+		}
+	}
+
+	return nil, fmt.Errorf("job %q not found", id)
+}
+
+// List returns a snapshot of every job currently tracked in memory.
+func (r *JobRegistry) List() []*Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, job.snapshot())
+	}
+	return jobs
+}
+
+// Stop cancels a running job's context. It is a no-op if the job has
+// already finished.
+func (r *JobRegistry) Stop(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return fmt.Errorf("job %q not found", id)
+	}
+	if job.FinishedAt != nil {
+		return nil
+	}
+	if job.cancel != nil {
+		job.cancel()
+	}
+	return nil
+}
+
+func (r *JobRegistry) jobPath(id string) string {
+	return filepath.Join(r.jobsDir, id+".json")
+}
+
+// persist writes job to disk. job must already be a detached snapshot (see
+// Job.snapshot) rather than the live, lock-guarded instance.
+func (r *JobRegistry) persist(job *Job) {
+	if r.jobsDir == "" {
+		return
+	}
+	if err := os.MkdirAll(r.jobsDir, 0o755); err != nil {
+		return
+	}
+	raw, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(r.jobPath(job.ID), raw, 0o644)
+}
+
+func (r *JobRegistry) loadFromDisk(id string) (*Job, error) {
+	raw, err := os.ReadFile(r.jobPath(id))
+	if err != nil {
+		return nil, err
+	}
+	var job Job
+	if err := json.Unmarshal(raw, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// AuditRunAsync starts AuditRun in the background against registry and
+// returns immediately with the new job's id.
+func AuditRunAsync(registry *JobRegistry, opts AuditOptions) (string, error) {
+	if opts.RunID == 0 {
+		return "", fmt.Errorf("audit: run id is required")
+	}
+
+	id := registry.Start(context.Background(), opts.RunID, func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error) {
+		report(JobStageFetching, 0.1)
+		data, err := AuditRun(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		report(JobStageAnalyzing, 0.9)
+		return data, nil
+	})
+
+	return id, nil
+}
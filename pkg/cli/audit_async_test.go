@@ -0,0 +1,193 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instantRegistry returns a JobRegistry whose retention sleep resolves
+// immediately, so expiry tests don't have to wait on a real clock.
+func instantRegistry(t *testing.T) *JobRegistry {
+	t.Helper()
+	r := NewJobRegistry(t.TempDir())
+	r.sleepFor = func(d time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	return r
+}
+
+func TestJobRegistry_StartAndStatus(t *testing.T) {
+	r := NewJobRegistry(t.TempDir())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	id := r.Start(context.Background(), 1, func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error) {
+		report(JobStageFetching, 0.5)
+		close(started)
+		<-release
+		return &AuditData{Overview: AuditOverview{RunID: 1}}, nil
+	})
+
+	<-started
+	job, err := r.Status(id)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if job.Stage != JobStageFetching {
+		t.Errorf("expected stage %q while running, got %q", JobStageFetching, job.Stage)
+	}
+
+	close(release)
+
+	// Poll briefly for the goroutine to record completion.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		job, err = r.Status(id)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if job.FinishedAt != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for job to finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if job.Result == nil || job.Result.Overview.RunID != 1 {
+		t.Errorf("expected result to be recorded, got %+v", job.Result)
+	}
+}
+
+func TestJobRegistry_ConcurrentAudits(t *testing.T) {
+	r := NewJobRegistry(t.TempDir())
+
+	const n = 8
+	ids := make([]string, n)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		i := i
+		ids[i] = r.Start(context.Background(), int64(i), func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error) {
+			return &AuditData{Overview: AuditOverview{RunID: int64(i)}}, nil
+		})
+	}
+
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			deadline := time.Now().Add(2 * time.Second)
+			for {
+				job, err := r.Status(ids[i])
+				if err != nil {
+					t.Errorf("Status(%q) error = %v", ids[i], err)
+					return
+				}
+				if job.FinishedAt != nil {
+					if job.Result.Overview.RunID != int64(i) {
+						t.Errorf("job %d: expected run id %d, got %d", i, i, job.Result.Overview.RunID)
+					}
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Errorf("job %d: timed out waiting for completion", i)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestJobRegistry_StopMidRun(t *testing.T) {
+	r := NewJobRegistry(t.TempDir())
+
+	started := make(chan struct{})
+	var cancelled bool
+	var mu sync.Mutex
+
+	id := r.Start(context.Background(), 1, func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error) {
+		close(started)
+		<-ctx.Done()
+		mu.Lock()
+		cancelled = true
+		mu.Unlock()
+		return nil, ctx.Err()
+	})
+
+	<-started
+	if err := r.Stop(id); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		done := cancelled
+		mu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for job to observe cancellation")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// fn returning is not the same as the registry recording the result;
+	// wait for FinishedAt before checking the recorded error.
+	var job *Job
+	for {
+		var err error
+		job, err = r.Status(id)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if job.FinishedAt != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for job to record completion")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if job.Error != context.Canceled.Error() {
+		t.Errorf("expected job to record a cancellation error, got %q", job.Error)
+	}
+}
+
+func TestJobRegistry_Expiry(t *testing.T) {
+	r := instantRegistry(t)
+
+	id := r.Start(context.Background(), 1, func(ctx context.Context, report func(stage JobStage, progress float64)) (*AuditData, error) {
+		return &AuditData{}, nil
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := r.Status(id); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("job never expired")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAuditRunAsync_RequiresRunID(t *testing.T) {
+	r := NewJobRegistry(t.TempDir())
+	if _, err := AuditRunAsync(r, AuditOptions{}); err == nil {
+		t.Fatal("expected error for missing run id")
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultJobsDir is where the job registry persists records so that
+// `gh aw job status` works from a separate process than the one that
+// started the async audit.
+const defaultJobsDir = ".github/aw/jobs"
+
+// defaultJobRegistry is the process-wide registry used by the audit and job
+// commands. It is a package var (rather than constructed per-command) so
+// that `--async` and `job status/list/stop` share the same in-memory state
+// within a single long-running process, while still persisting to disk for
+// cross-process lookups.
+var defaultJobRegistry = NewJobRegistry(defaultJobsDir)
+
+// NewAuditCommand creates the `gh aw audit` command.
+func NewAuditCommand() *cobra.Command {
+	var async bool
+	var jqFilter string
+	var logsDir string
+	var output string
+	var section string
+	var columns []string
+	var tmplSource string
+	var sinksConfig string
+
+	cmd := &cobra.Command{
+		Use:   "audit <run-id>",
+		Short: "Audit a workflow run's logs, cost, and tool usage",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid run id %q: %w", args[0], err)
+			}
+
+			opts := AuditOptions{RunID: runID, LogsDir: logsDir}
+
+			if sinksConfig != "" {
+				raw, err := os.ReadFile(sinksConfig)
+				if err != nil {
+					return fmt.Errorf("audit: failed to read sinks config %q: %w", sinksConfig, err)
+				}
+				sinks, err := ParseAuditSinks(raw)
+				if err != nil {
+					return err
+				}
+				opts.Sinks = sinks
+			}
+
+			if async {
+				jobID, err := AuditRunAsync(defaultJobRegistry, opts)
+				if err != nil {
+					return err
+				}
+				out, err := json.Marshal(map[string]string{"jobid": jobID})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+
+			data, err := AuditRun(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			format := OutputFormat(output)
+			if format == "" {
+				format = OutputFormatJSON
+			}
+			if jqFilter != "" && output == "" {
+				format = OutputFormatJQ
+			}
+
+			rendered, err := RenderAudit(data, format, RenderOpts{
+				JqFilter: jqFilter,
+				Section:  section,
+				Columns:  columns,
+				Template: tmplSource,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), rendered)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&async, "async", false, "start the audit in the background and print a job id")
+	cmd.Flags().StringVar(&jqFilter, "jq", "", "filter the audit output with a jq expression")
+	cmd.Flags().StringVar(&logsDir, "logs-dir", ".github/aw/logs", "directory containing cached run logs")
+	cmd.Flags().StringVar(&output, "output", "", "output format: json, jq, table, csv, tsv, template (default json)")
+	cmd.Flags().StringVar(&section, "section", "jobs", "AuditData section to render for table/csv/tsv: jobs, missing_tools, tool_usage, downloaded_files, warnings")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "comma-separated list of columns to render for table/csv/tsv")
+	cmd.Flags().StringVar(&tmplSource, "template", "", "Go text/template source, used with --output template")
+	cmd.Flags().StringVar(&sinksConfig, "sinks-config", "", "path to a YAML file declaring audit.sinks to route this run's data to")
+
+	cmd.AddCommand(NewAuditDiffCommand())
+
+	return cmd
+}
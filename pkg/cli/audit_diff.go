@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffEntryKind classifies a single AuditDiff entry.
+type DiffEntryKind string
+
+const (
+	DiffKindChanged DiffEntryKind = "changed"
+	DiffKindAdded   DiffEntryKind = "added"
+	DiffKindRemoved DiffEntryKind = "removed"
+)
+
+// DiffEntry is a single difference found between two audit JSON trees at a
+// given jq-style path.
+type DiffEntry struct {
+	Path  string        `json:"path"`
+	Kind  DiffEntryKind `json:"kind"`
+	Old   any           `json:"old,omitempty"`
+	New   any           `json:"new,omitempty"`
+	Delta float64       `json:"delta,omitempty"`
+	Pct   float64       `json:"pct,omitempty"`
+}
+
+// AuditDiff is the full result of comparing two audit runs.
+type AuditDiff struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+// ArrayKey tells DiffAudits to diff an array at Path as a set, keyed by the
+// given field name within each element, rather than by index.
+type ArrayKey struct {
+	Path string
+	Key  string
+}
+
+// Threshold fails DiffAudits' caller (via AuditDiff.CheckThresholds) when
+// the numeric field at Path regresses beyond PctPoints percent.
+type Threshold struct {
+	Path      string
+	PctPoints float64
+}
+
+// DiffOpts configures DiffAudits.
+type DiffOpts struct {
+	ArrayKeys  []ArrayKey
+	Thresholds []Threshold
+}
+
+// DiffAudits walks a and b's JSON trees and reports every difference as a
+// DiffEntry keyed by jq-style path (e.g. ".metrics.token_usage").
+func DiffAudits(a, b *AuditData, opts DiffOpts) (*AuditDiff, error) {
+	aJSON, err := toAny(a)
+	if err != nil {
+		return nil, fmt.Errorf("diff: failed to marshal first run: %w", err)
+	}
+	bJSON, err := toAny(b)
+	if err != nil {
+		return nil, fmt.Errorf("diff: failed to marshal second run: %w", err)
+	}
+
+	keyFor := make(map[string]string, len(opts.ArrayKeys))
+	for _, ak := range opts.ArrayKeys {
+		keyFor[ak.Path] = ak.Key
+	}
+
+	var entries []DiffEntry
+	walkDiff("", aJSON, bJSON, keyFor, &entries)
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	return &AuditDiff{Entries: entries}, nil
+}
+
+func toAny(data *AuditData) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func walkDiff(path string, a, b any, keyFor map[string]string, entries *[]DiffEntry) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			*entries = append(*entries, DiffEntry{Path: path, Kind: DiffKindChanged, Old: a, New: b})
+			return
+		}
+		keys := map[string]bool{}
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		for k := range keys {
+			childPath := path + "." + k
+			aChild, aOK := av[k]
+			bChild, bOK := bv[k]
+			switch {
+			case aOK && !bOK:
+				*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindRemoved, Old: aChild})
+			case !aOK && bOK:
+				*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindAdded, New: bChild})
+			default:
+				walkDiff(childPath, aChild, bChild, keyFor, entries)
+			}
+		}
+
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			*entries = append(*entries, DiffEntry{Path: path, Kind: DiffKindChanged, Old: a, New: b})
+			return
+		}
+		if key, ok := keyFor[path]; ok {
+			diffKeyedArray(path, key, av, bv, keyFor, entries)
+			return
+		}
+		diffArrayByIndex(path, av, bv, keyFor, entries)
+
+	default:
+		diffScalar(path, a, b, entries)
+	}
+}
+
+func diffArrayByIndex(path string, a, b []any, keyFor map[string]string, entries *[]DiffEntry) {
+	max := len(a)
+	if len(b) > max {
+		max = len(b)
+	}
+	for i := 0; i < max; i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(b):
+			*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindRemoved, Old: a[i]})
+		case i >= len(a):
+			*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindAdded, New: b[i]})
+		default:
+			walkDiff(childPath, a[i], b[i], keyFor, entries)
+		}
+	}
+}
+
+// diffKeyedArray set-diffs two arrays of objects, matching elements by the
+// string value of field key rather than by position. keyFor is threaded
+// through to the recursive walkDiff calls so a nested array inside a
+// matched element can itself be set-diffed by its own --array-key.
+func diffKeyedArray(path, key string, a, b []any, keyFor map[string]string, entries *[]DiffEntry) {
+	index := func(items []any) map[string]any {
+		m := make(map[string]any, len(items))
+		for _, item := range items {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			k := fmt.Sprintf("%v", obj[key])
+			m[k] = item
+		}
+		return m
+	}
+
+	aIndex := index(a)
+	bIndex := index(b)
+
+	keys := map[string]bool{}
+	for k := range aIndex {
+		keys[k] = true
+	}
+	for k := range bIndex {
+		keys[k] = true
+	}
+
+	for k := range keys {
+		childPath := fmt.Sprintf("%s[%s=%s]", path, key, k)
+		aItem, aOK := aIndex[k]
+		bItem, bOK := bIndex[k]
+		switch {
+		case aOK && !bOK:
+			*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindRemoved, Old: aItem})
+		case !aOK && bOK:
+			*entries = append(*entries, DiffEntry{Path: childPath, Kind: DiffKindAdded, New: bItem})
+		default:
+			walkDiff(childPath, aItem, bItem, keyFor, entries)
+		}
+	}
+}
+
+func diffScalar(path string, a, b any, entries *[]DiffEntry) {
+	if a == b {
+		return
+	}
+
+	an, aIsNum := a.(float64)
+	bn, bIsNum := b.(float64)
+	if aIsNum && bIsNum {
+		delta := bn - an
+		var pct float64
+		if an != 0 {
+			pct = delta / an * 100
+		}
+		*entries = append(*entries, DiffEntry{Path: path, Kind: DiffKindChanged, Old: an, New: bn, Delta: delta, Pct: pct})
+		return
+	}
+
+	*entries = append(*entries, DiffEntry{Path: path, Kind: DiffKindChanged, Old: a, New: b})
+}
+
+// CheckThresholds reports whether any Threshold in thresholds was breached
+// by a regression (a positive delta beyond PctPoints) in diff, returning the
+// breached entries.
+func (d *AuditDiff) CheckThresholds(thresholds []Threshold) []DiffEntry {
+	limitFor := make(map[string]float64, len(thresholds))
+	for _, th := range thresholds {
+		limitFor[th.Path] = th.PctPoints
+	}
+
+	var breached []DiffEntry
+	for _, entry := range d.Entries {
+		limit, ok := limitFor[entry.Path]
+		if !ok {
+			continue
+		}
+		// A zero baseline makes Pct undefined (it's left at 0 by diffScalar
+		// since there's no meaningful percentage of zero); treat any
+		// positive delta off a zero baseline as a breach rather than
+		// silently passing it through.
+		if old, isNum := entry.Old.(float64); isNum && old == 0 && entry.Delta > 0 {
+			breached = append(breached, entry)
+			continue
+		}
+		if entry.Pct > limit {
+			breached = append(breached, entry)
+		}
+	}
+	return breached
+}
+
+// ParseThresholdFlag parses a "--threshold .path=N%" flag value into a
+// Threshold.
+func ParseThresholdFlag(s string) (Threshold, error) {
+	path, pctStr, ok := strings.Cut(s, "=")
+	if !ok {
+		return Threshold{}, fmt.Errorf("diff: invalid --threshold %q, expected \"<path>=<percent>%%\"", s)
+	}
+	pctStr = strings.TrimSuffix(strings.TrimSpace(pctStr), "%")
+	pct, err := strconv.ParseFloat(pctStr, 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("diff: invalid --threshold percent in %q: %w", s, err)
+	}
+	return Threshold{Path: strings.TrimSpace(path), PctPoints: pct}, nil
+}
+
+// ParseArrayKeyFlag parses a "--array-key .jobs=name" flag value into an
+// ArrayKey.
+func ParseArrayKeyFlag(s string) (ArrayKey, error) {
+	path, key, ok := strings.Cut(s, "=")
+	if !ok {
+		return ArrayKey{}, fmt.Errorf("diff: invalid --array-key %q, expected \"<path>=<key field>\"", s)
+	}
+	return ArrayKey{Path: strings.TrimSpace(path), Key: strings.TrimSpace(key)}, nil
+}
+
+// diffRow carries the table tags RenderDiff exposes for DiffEntry, used
+// by the multi-format renderer's table/csv/tsv paths.
+type diffRow struct {
+	Path  string `table:"path"`
+	Kind  string `table:"kind"`
+	Old   string `table:"old"`
+	New   string `table:"new"`
+	Delta string `table:"delta"`
+	Pct   string `table:"pct"`
+}
+
+// entriesToRows converts DiffEntry values (whose Old/New/Delta/Pct are
+// typed for JSON) into diffRow values (all fields pre-formatted strings) for
+// table/csv/tsv rendering.
+func entriesToRows(entries []DiffEntry) []diffRow {
+	rows := make([]diffRow, len(entries))
+	for i, e := range entries {
+		rows[i] = diffRow{
+			Path:  e.Path,
+			Kind:  string(e.Kind),
+			Old:   fmt.Sprintf("%v", e.Old),
+			New:   fmt.Sprintf("%v", e.New),
+			Delta: fmt.Sprintf("%g", e.Delta),
+			Pct:   fmt.Sprintf("%.2f%%", e.Pct),
+		}
+	}
+	return rows
+}
+
+// RenderDiff formats an AuditDiff the same way RenderAudit formats AuditData:
+// if opts.JqFilter is set it runs first; for table/csv/tsv the filtered
+// result is re-parsed as diff entries and rendered the same as the
+// unfiltered path, and for template it is re-parsed as arbitrary JSON. Either
+// re-parse fails with an error if the filter reshaped the data into
+// something that no longer fits.
+func RenderDiff(diff *AuditDiff, format OutputFormat, opts RenderOpts) (string, error) {
+	raw, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("diff: failed to marshal diff: %w", err)
+	}
+
+	if opts.JqFilter != "" {
+		filtered, err := ApplyJqFilter(string(raw), opts.JqFilter)
+		if err != nil {
+			return "", err
+		}
+		switch format {
+		case "", OutputFormatJSON, OutputFormatJQ:
+			return filtered, nil
+		case OutputFormatTable, OutputFormatCSV, OutputFormatTSV:
+			var entries []DiffEntry
+			if err := json.Unmarshal([]byte(filtered), &entries); err != nil {
+				return "", fmt.Errorf("render: --jq result does not match the shape of diff entries for --output %s: %w", format, err)
+			}
+			return renderRowsTable(entriesToRows(entries), format, opts.Columns)
+		case OutputFormatTemplate:
+			var v any
+			if err := json.Unmarshal([]byte(filtered), &v); err != nil {
+				return "", fmt.Errorf("render: --jq result is not valid JSON for --output template: %w", err)
+			}
+			return renderTemplateValue(v, opts)
+		default:
+			return "", fmt.Errorf("render: unsupported output format %q", format)
+		}
+	}
+
+	switch format {
+	case "", OutputFormatJSON:
+		return string(raw) + "\n", nil
+	case OutputFormatJQ:
+		if opts.JqFilter == "" {
+			return "", fmt.Errorf("render: --jq requires a filter expression")
+		}
+		return ApplyJqFilter(string(raw), opts.JqFilter)
+	case OutputFormatTable, OutputFormatCSV, OutputFormatTSV:
+		return renderRowsTable(entriesToRows(diff.Entries), format, opts.Columns)
+	case OutputFormatTemplate:
+		return renderTemplateValue(diff, opts)
+	default:
+		return "", fmt.Errorf("render: unsupported output format %q", format)
+	}
+}
@@ -0,0 +1,270 @@
+//go:build !integration
+
+package cli
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func diffFixtures() (a, b *AuditData) {
+	a = &AuditData{
+		Overview: AuditOverview{RunID: 1, WorkflowName: "Test Workflow", Conclusion: "success"},
+		Metrics:  AuditMetrics{TokenUsage: 15234, WarningCount: 1},
+		Jobs: []AuditJob{
+			{Name: "agent", Status: "completed", Conclusion: "success"},
+		},
+		Warnings: []AuditWarning{
+			{Message: "Using deprecated syntax"},
+		},
+	}
+	b = &AuditData{
+		Overview: AuditOverview{RunID: 2, WorkflowName: "Test Workflow", Conclusion: "success"},
+		Metrics:  AuditMetrics{TokenUsage: 22100, WarningCount: 2},
+		Jobs: []AuditJob{
+			{Name: "agent", Status: "completed", Conclusion: "success"},
+			{Name: "post-process", Status: "completed", Conclusion: "success"},
+		},
+		Warnings: []AuditWarning{
+			{Message: "Using deprecated syntax"},
+			{Message: "MCP server slow to respond"},
+		},
+	}
+	return a, b
+}
+
+func findEntry(entries []DiffEntry, path string) (DiffEntry, bool) {
+	for _, e := range entries {
+		if e.Path == path {
+			return e, true
+		}
+	}
+	return DiffEntry{}, false
+}
+
+func TestDiffAudits_TokenUsageDelta(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	entry, ok := findEntry(diff.Entries, ".metrics.token_usage")
+	if !ok {
+		t.Fatal("expected a diff entry for .metrics.token_usage")
+	}
+	if entry.Old != float64(15234) || entry.New != float64(22100) {
+		t.Errorf("unexpected old/new: %+v", entry)
+	}
+	if entry.Delta != 6866 {
+		t.Errorf("expected delta 6866, got %v", entry.Delta)
+	}
+	wantPct := 6866.0 / 15234.0 * 100
+	if diffFloat(entry.Pct, wantPct) > 0.01 {
+		t.Errorf("expected pct ~%.2f, got %.2f", wantPct, entry.Pct)
+	}
+}
+
+func diffFloat(a, b float64) float64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestDiffAudits_JobsSetDiffByKey(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{ArrayKeys: []ArrayKey{{Path: ".jobs", Key: "name"}}})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	entry, ok := findEntry(diff.Entries, ".jobs[name=post-process]")
+	if !ok {
+		t.Fatal("expected a set-diff entry for the added post-process job")
+	}
+	if entry.Kind != DiffKindAdded {
+		t.Errorf("expected kind added, got %q", entry.Kind)
+	}
+}
+
+func TestDiffAudits_WarningsLengthChange(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	entry, ok := findEntry(diff.Entries, ".metrics.warning_count")
+	if !ok {
+		t.Fatal("expected a diff entry for .metrics.warning_count")
+	}
+	if entry.Old != float64(1) || entry.New != float64(2) {
+		t.Errorf("unexpected old/new: %+v", entry)
+	}
+}
+
+func TestDiffAudits_ConclusionUnchanged(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	if _, ok := findEntry(diff.Entries, ".overview.conclusion"); ok {
+		t.Error("expected no diff entry for an unchanged field")
+	}
+}
+
+func TestDiffAudits_EntriesAreSorted(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	paths := make([]string, len(diff.Entries))
+	for i, e := range diff.Entries {
+		paths[i] = e.Path
+	}
+	if !sort.StringsAreSorted(paths) {
+		t.Errorf("expected sorted paths, got %v", paths)
+	}
+}
+
+func TestAuditDiff_CheckThresholds(t *testing.T) {
+	a, b := diffFixtures()
+
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	breached := diff.CheckThresholds([]Threshold{{Path: ".metrics.token_usage", PctPoints: 10}})
+	if len(breached) != 1 {
+		t.Fatalf("expected 1 breached threshold, got %d: %+v", len(breached), breached)
+	}
+
+	notBreached := diff.CheckThresholds([]Threshold{{Path: ".metrics.token_usage", PctPoints: 80}})
+	if len(notBreached) != 0 {
+		t.Errorf("expected no breached thresholds at 80%%, got %+v", notBreached)
+	}
+}
+
+func TestParseThresholdFlag(t *testing.T) {
+	th, err := ParseThresholdFlag(".metrics.token_usage=10%")
+	if err != nil {
+		t.Fatalf("ParseThresholdFlag() error = %v", err)
+	}
+	if th.Path != ".metrics.token_usage" || th.PctPoints != 10 {
+		t.Errorf("unexpected threshold: %+v", th)
+	}
+
+	if _, err := ParseThresholdFlag("no-equals-sign"); err == nil {
+		t.Error("expected an error for a malformed threshold flag")
+	}
+}
+
+func TestParseArrayKeyFlag(t *testing.T) {
+	ak, err := ParseArrayKeyFlag(".jobs=name")
+	if err != nil {
+		t.Fatalf("ParseArrayKeyFlag() error = %v", err)
+	}
+	if ak.Path != ".jobs" || ak.Key != "name" {
+		t.Errorf("unexpected array key: %+v", ak)
+	}
+
+	if _, err := ParseArrayKeyFlag("no-equals-sign"); err == nil {
+		t.Error("expected an error for a malformed array-key flag")
+	}
+}
+
+func TestRenderDiff_Table(t *testing.T) {
+	a, b := diffFixtures()
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	out, err := RenderDiff(diff, OutputFormatTable, RenderOpts{})
+	if err != nil {
+		t.Fatalf("RenderDiff() error = %v", err)
+	}
+	if out == "" {
+		t.Error("expected non-empty table output")
+	}
+}
+
+func TestRenderDiff_Jq(t *testing.T) {
+	a, b := diffFixtures()
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	out, err := RenderDiff(diff, OutputFormatJQ, RenderOpts{JqFilter: ".entries | length"})
+	if err != nil {
+		t.Fatalf("RenderDiff() error = %v", err)
+	}
+	if out == "" {
+		t.Error("expected non-empty jq output")
+	}
+}
+
+func TestRenderDiff_JqThenTable(t *testing.T) {
+	a, b := diffFixtures()
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	out, err := RenderDiff(diff, OutputFormatTable, RenderOpts{JqFilter: `.entries | map(select(.kind == "added"))`})
+	if err != nil {
+		t.Fatalf("RenderDiff() error = %v", err)
+	}
+	if !strings.Contains(out, "added") {
+		t.Errorf("expected filtered added entries in table output, got %q", out)
+	}
+	if strings.Contains(out, "changed") {
+		t.Errorf("expected changed entries to be filtered out, got %q", out)
+	}
+}
+
+func TestRenderDiff_JqThenTemplate(t *testing.T) {
+	a, b := diffFixtures()
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	out, err := RenderDiff(diff, OutputFormatTemplate, RenderOpts{
+		JqFilter: ".entries | length",
+		Template: "{{ . }} entries",
+	})
+	if err != nil {
+		t.Fatalf("RenderDiff() error = %v", err)
+	}
+	if !strings.HasSuffix(out, " entries") {
+		t.Errorf("unexpected template output: %q", out)
+	}
+}
+
+func TestRenderDiff_JqRejectsMismatchedTabularShape(t *testing.T) {
+	a, b := diffFixtures()
+	diff, err := DiffAudits(a, b, DiffOpts{})
+	if err != nil {
+		t.Fatalf("DiffAudits() error = %v", err)
+	}
+
+	for _, format := range []OutputFormat{OutputFormatTable, OutputFormatCSV, OutputFormatTSV} {
+		if _, err := RenderDiff(diff, format, RenderOpts{JqFilter: ".entries | length"}); err == nil {
+			t.Errorf("expected error rendering non-row-shaped --jq result as --output %s", format)
+		}
+	}
+}
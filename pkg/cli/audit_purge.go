@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PurgePolicy configures which cached run directories under a logs root are
+// eligible for deletion by RunPurge.
+type PurgePolicy struct {
+	// RetainDays keeps any run newer than this many days, regardless of
+	// RetainCount.
+	RetainDays int
+	// RetainCount always keeps the N most recently modified runs,
+	// regardless of RetainDays.
+	RetainCount int
+	// Include, if non-empty, restricts purging to runs whose workflow name
+	// (read from their cached audit.json) matches one of these glob
+	// patterns.
+	Include []string
+	// Exclude skips runs whose workflow name matches one of these glob
+	// patterns, even if Include also matches.
+	Exclude []string
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+}
+
+// PurgedRun describes a single run directory affected by a purge.
+type PurgedRun struct {
+	RunID        int64  `json:"run_id"`
+	Path         string `json:"path"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	ModifiedAt   string `json:"modified_at"`
+	Bytes        int64  `json:"bytes"`
+}
+
+// PurgeReport is the outcome of a single RunPurge call, and is also what
+// gets appended to ".github/aw/purge-history.json".
+type PurgeReport struct {
+	ID           string      `json:"id"`
+	StartedAt    time.Time   `json:"started_at"`
+	FinishedAt   time.Time   `json:"finished_at"`
+	DeletedCount int         `json:"deleted_count"`
+	FreedBytes   int64       `json:"freed_bytes"`
+	Policy       PurgePolicy `json:"policy"`
+	Status       string      `json:"status"`
+	Error        string      `json:"error,omitempty"`
+	Deleted      []PurgedRun `json:"deleted,omitempty"`
+	Skipped      []PurgedRun `json:"skipped,omitempty"`
+}
+
+// ActiveJobLister reports which run ids currently have an in-flight async
+// audit job, so RunPurge can avoid deleting logs out from under them.
+type ActiveJobLister interface {
+	List() []*Job
+}
+
+// RunPurge enumerates cached run directories under logsDir, applies
+// policy, and deletes (or, if policy.DryRun, reports) the runs that fall
+// outside retention. A run directory owned by a currently-running async
+// audit job (per registry) is always skipped.
+func RunPurge(ctx context.Context, logsDir string, policy PurgePolicy, registry ActiveJobLister) (*PurgeReport, error) {
+	report := &PurgeReport{
+		ID:        fmt.Sprintf("purge-%d", time.Now().UnixNano()),
+		StartedAt: now(),
+		Policy:    policy,
+		Status:    "ok",
+	}
+
+	runs, err := discoverRuns(logsDir)
+	if err != nil {
+		report.FinishedAt = now()
+		report.Status = "error"
+		report.Error = err.Error()
+		return report, err
+	}
+
+	activeRunIDs := map[int64]bool{}
+	if registry != nil {
+		for _, job := range registry.List() {
+			if job.FinishedAt == nil {
+				activeRunIDs[job.RunID] = true
+			}
+		}
+	}
+
+	keep := selectRunsToKeep(runs, policy)
+
+	for _, run := range runs {
+		select {
+		case <-ctx.Done():
+			report.FinishedAt = now()
+			report.Status = "error"
+			report.Error = ctx.Err().Error()
+			return report, ctx.Err()
+		default:
+		}
+
+		if keep[run.RunID] {
+			continue
+		}
+		if activeRunIDs[run.RunID] {
+			report.Skipped = append(report.Skipped, run)
+			continue
+		}
+		if !matchesIncludeExclude(run.WorkflowName, policy.Include, policy.Exclude) {
+			continue
+		}
+
+		if !policy.DryRun {
+			if err := os.RemoveAll(run.Path); err != nil {
+				report.FinishedAt = now()
+				report.Status = "error"
+				report.Error = err.Error()
+				return report, err
+			}
+		}
+
+		report.Deleted = append(report.Deleted, run)
+		report.DeletedCount++
+		report.FreedBytes += run.Bytes
+	}
+
+	report.FinishedAt = now()
+	return report, nil
+}
+
+// discoverRuns walks logsDir for "run-<id>" directories, stamping each with
+// its modification time, size on disk, and (when available) workflow name.
+func discoverRuns(logsDir string) ([]PurgedRun, error) {
+	entries, err := os.ReadDir(logsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("purge: failed to list %s: %w", logsDir, err)
+	}
+
+	var runs []PurgedRun
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "run-") {
+			continue
+		}
+		runID, err := strconv.ParseInt(strings.TrimPrefix(entry.Name(), "run-"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(logsDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		run := PurgedRun{
+			RunID:      runID,
+			Path:       path,
+			ModifiedAt: info.ModTime().UTC().Format(time.RFC3339),
+		}
+
+		if data, err := readCachedAuditData(path); err == nil {
+			run.WorkflowName = data.Overview.WorkflowName
+		}
+		run.Bytes = dirSize(path)
+
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+func readCachedAuditData(runPath string) (*AuditData, error) {
+	raw, err := os.ReadFile(filepath.Join(runPath, "audit.json"))
+	if err != nil {
+		return nil, err
+	}
+	var data AuditData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// selectRunsToKeep returns the set of run ids retention always keeps: the
+// RetainCount most recently modified runs, plus anything modified within
+// RetainDays.
+func selectRunsToKeep(runs []PurgedRun, policy PurgePolicy) map[int64]bool {
+	keep := make(map[int64]bool)
+
+	sorted := make([]PurgedRun, len(runs))
+	copy(sorted, runs)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j].ModifiedAt > sorted[j-1].ModifiedAt; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+
+	for i, run := range sorted {
+		if policy.RetainCount > 0 && i < policy.RetainCount {
+			keep[run.RunID] = true
+		}
+	}
+
+	if policy.RetainDays > 0 {
+		cutoff := now().AddDate(0, 0, -policy.RetainDays)
+		for _, run := range runs {
+			modified, err := time.Parse(time.RFC3339, run.ModifiedAt)
+			if err == nil && modified.After(cutoff) {
+				keep[run.RunID] = true
+			}
+		}
+	}
+
+	return keep
+}
+
+// defaultPurgeHistoryPath is where purge execution history is persisted,
+// relative to the repository root.
+const defaultPurgeHistoryPath = ".github/aw/purge-history.json"
+
+// AppendPurgeHistory records report to the purge history file, creating it
+// if necessary.
+func AppendPurgeHistory(historyPath string, report *PurgeReport) error {
+	history, err := LoadPurgeHistory(historyPath)
+	if err != nil {
+		return err
+	}
+	history = append(history, *report)
+
+	if dir := filepath.Dir(historyPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("purge: failed to create %s: %w", dir, err)
+		}
+	}
+
+	raw, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("purge: failed to marshal history: %w", err)
+	}
+	if err := os.WriteFile(historyPath, raw, 0o644); err != nil {
+		return fmt.Errorf("purge: failed to write %s: %w", historyPath, err)
+	}
+	return nil
+}
+
+// LoadPurgeHistory reads every recorded purge execution, oldest first. A
+// missing history file is treated as an empty history, not an error.
+func LoadPurgeHistory(historyPath string) ([]PurgeReport, error) {
+	raw, err := os.ReadFile(historyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("purge: failed to read %s: %w", historyPath, err)
+	}
+
+	var history []PurgeReport
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("purge: failed to parse %s: %w", historyPath, err)
+	}
+	return history, nil
+}
+
+func matchesIncludeExclude(workflowName string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, workflowName); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := filepath.Match(pattern, workflowName); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeJobLister struct {
+	jobs []*Job
+}
+
+func (f fakeJobLister) List() []*Job { return f.jobs }
+
+func makeRunDir(t *testing.T, logsDir string, runID int64, workflowName string, mtime time.Time) {
+	t.Helper()
+	dir := runDir(logsDir, runID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create run dir: %v", err)
+	}
+	raw, _ := json.Marshal(AuditData{Overview: AuditOverview{RunID: runID, WorkflowName: workflowName}})
+	if err := os.WriteFile(filepath.Join(dir, "audit.json"), raw, 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if err := os.Chtimes(dir, mtime, mtime); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+}
+
+func TestRunPurge_RetainCountDryRun(t *testing.T) {
+	logsDir := t.TempDir()
+	base := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// 5 runs, oldest to newest, 10 days apart.
+	for i := int64(1); i <= 5; i++ {
+		makeRunDir(t, logsDir, i, "wf", base.AddDate(0, 0, int(i)*10))
+	}
+
+	realNow := now
+	now = func() time.Time { return base.AddDate(0, 0, 51) }
+	defer func() { now = realNow }()
+
+	report, err := RunPurge(context.Background(), logsDir, PurgePolicy{RetainCount: 2, RetainDays: 0, DryRun: true}, nil)
+	if err != nil {
+		t.Fatalf("RunPurge() error = %v", err)
+	}
+	if report.DeletedCount != 3 {
+		t.Errorf("expected 3 runs eligible for deletion, got %d: %+v", report.DeletedCount, report.Deleted)
+	}
+
+	entries, err := os.ReadDir(logsDir)
+	if err != nil {
+		t.Fatalf("failed to list logsDir: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Errorf("dry run should not delete anything, found %d entries", len(entries))
+	}
+}
+
+func TestRunPurge_ActuallyDeletes(t *testing.T) {
+	logsDir := t.TempDir()
+	base := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	makeRunDir(t, logsDir, 1, "wf", base)
+	makeRunDir(t, logsDir, 2, "wf", base.AddDate(0, 0, 1))
+
+	realNow := now
+	now = func() time.Time { return base.AddDate(0, 0, 2) }
+	defer func() { now = realNow }()
+
+	report, err := RunPurge(context.Background(), logsDir, PurgePolicy{RetainCount: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunPurge() error = %v", err)
+	}
+	if report.DeletedCount != 1 {
+		t.Fatalf("expected 1 deleted run, got %d", report.DeletedCount)
+	}
+	if report.Deleted[0].RunID != 1 {
+		t.Errorf("expected the oldest run to be deleted, got run %d", report.Deleted[0].RunID)
+	}
+
+	if _, err := os.Stat(runDir(logsDir, 1)); !os.IsNotExist(err) {
+		t.Errorf("expected run 1's directory to be removed")
+	}
+	if _, err := os.Stat(runDir(logsDir, 2)); err != nil {
+		t.Errorf("expected run 2's directory to remain: %v", err)
+	}
+}
+
+func TestRunPurge_SkipsActiveJobs(t *testing.T) {
+	logsDir := t.TempDir()
+	base := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	makeRunDir(t, logsDir, 1, "wf", base)
+	makeRunDir(t, logsDir, 2, "wf", base.AddDate(0, 0, 1))
+
+	realNow := now
+	now = func() time.Time { return base.AddDate(0, 0, 2) }
+	defer func() { now = realNow }()
+
+	lister := fakeJobLister{jobs: []*Job{
+		{RunID: 1}, // in-flight: no FinishedAt yet
+	}}
+
+	report, err := RunPurge(context.Background(), logsDir, PurgePolicy{RetainCount: 0}, lister)
+	if err != nil {
+		t.Fatalf("RunPurge() error = %v", err)
+	}
+	if report.DeletedCount != 1 || report.Deleted[0].RunID != 2 {
+		t.Errorf("expected only run 2 to be deleted, got %+v", report.Deleted)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].RunID != 1 {
+		t.Errorf("expected run 1 to be reported as skipped, got %+v", report.Skipped)
+	}
+}
+
+func TestRunPurge_IncludeExclude(t *testing.T) {
+	logsDir := t.TempDir()
+	base := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	makeRunDir(t, logsDir, 1, "nightly-scan", base)
+	makeRunDir(t, logsDir, 2, "release", base)
+
+	realNow := now
+	now = func() time.Time { return base.AddDate(0, 1, 0) }
+	defer func() { now = realNow }()
+
+	report, err := RunPurge(context.Background(), logsDir, PurgePolicy{Include: []string{"nightly-*"}}, nil)
+	if err != nil {
+		t.Fatalf("RunPurge() error = %v", err)
+	}
+	if report.DeletedCount != 1 || report.Deleted[0].RunID != 1 {
+		t.Errorf("expected only the nightly-scan run to be purged, got %+v", report.Deleted)
+	}
+}
+
+func TestAppendAndLoadPurgeHistory(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "purge-history.json")
+
+	report1 := &PurgeReport{ID: "purge-1", DeletedCount: 2, Status: "ok"}
+	report2 := &PurgeReport{ID: "purge-2", DeletedCount: 0, Status: "ok"}
+
+	if err := AppendPurgeHistory(historyPath, report1); err != nil {
+		t.Fatalf("AppendPurgeHistory() error = %v", err)
+	}
+	if err := AppendPurgeHistory(historyPath, report2); err != nil {
+		t.Fatalf("AppendPurgeHistory() error = %v", err)
+	}
+
+	history, err := LoadPurgeHistory(historyPath)
+	if err != nil {
+		t.Fatalf("LoadPurgeHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ID != "purge-1" || history[1].ID != "purge-2" {
+		t.Errorf("unexpected history: %+v", history)
+	}
+}
+
+func TestLoadPurgeHistory_MissingFileIsEmpty(t *testing.T) {
+	history, err := LoadPurgeHistory(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadPurgeHistory() error = %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("expected empty history, got %+v", history)
+	}
+}
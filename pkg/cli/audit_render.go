@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// OutputFormat selects how RenderAudit presents audit results.
+type OutputFormat string
+
+const (
+	OutputFormatJSON     OutputFormat = "json"
+	OutputFormatJQ       OutputFormat = "jq"
+	OutputFormatTable    OutputFormat = "table"
+	OutputFormatCSV      OutputFormat = "csv"
+	OutputFormatTSV      OutputFormat = "tsv"
+	OutputFormatTemplate OutputFormat = "template"
+)
+
+// RenderOpts controls section/column selection and template rendering.
+type RenderOpts struct {
+	// JqFilter, if set, is applied to the audit JSON before formatting.
+	JqFilter string
+	// Section selects which AuditData slice to render for table/csv/tsv,
+	// e.g. "jobs", "missing_tools", "tool_usage", "downloaded_files",
+	// "warnings". Defaults to "jobs".
+	Section string
+	// Columns restricts table/csv/tsv output to the named fields, matched
+	// against each section struct's `table` tag. Empty means all columns.
+	Columns []string
+	// Template is the text/template source used by OutputFormatTemplate.
+	Template string
+}
+
+// auditSections maps a --section name to the corresponding AuditData slice.
+func auditSections(data *AuditData) map[string]any {
+	return map[string]any{
+		"jobs":             data.Jobs,
+		"missing_tools":    data.MissingTools,
+		"tool_usage":       data.ToolUsage,
+		"downloaded_files": data.DownloadedFiles,
+		"warnings":         data.Warnings,
+	}
+}
+
+// RenderAudit formats data according to format. If opts.JqFilter is set, it
+// runs first; for table/csv/tsv the filtered result is re-parsed as rows for
+// opts.Section and rendered the same as the unfiltered path, and for
+// template it is re-parsed as arbitrary JSON and handed to the template
+// directly. Either re-parse fails with an error if the filter reshaped the
+// data into something that no longer fits.
+func RenderAudit(data *AuditData, format OutputFormat, opts RenderOpts) (string, error) {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("render: failed to marshal audit data: %w", err)
+	}
+
+	if opts.JqFilter != "" {
+		filtered, err := ApplyJqFilter(string(raw), opts.JqFilter)
+		if err != nil {
+			return "", err
+		}
+		switch format {
+		case "", OutputFormatJSON, OutputFormatJQ:
+			return filtered, nil
+		case OutputFormatTable, OutputFormatCSV, OutputFormatTSV:
+			rows, err := filteredSectionRows(filtered, data, opts.Section)
+			if err != nil {
+				return "", fmt.Errorf("render: --jq result does not match the shape of section %q for --output %s: %w", sectionOrDefault(opts.Section), format, err)
+			}
+			return renderRowsTable(rows, format, opts.Columns)
+		case OutputFormatTemplate:
+			var v any
+			if err := json.Unmarshal([]byte(filtered), &v); err != nil {
+				return "", fmt.Errorf("render: --jq result is not valid JSON for --output template: %w", err)
+			}
+			return renderTemplateValue(v, opts)
+		default:
+			return "", fmt.Errorf("render: unsupported output format %q", format)
+		}
+	}
+
+	switch format {
+	case "", OutputFormatJSON:
+		return string(raw) + "\n", nil
+	case OutputFormatJQ:
+		if opts.JqFilter == "" {
+			return "", fmt.Errorf("render: --jq requires a filter expression")
+		}
+		return ApplyJqFilter(string(raw), opts.JqFilter)
+	case OutputFormatTable:
+		return renderTable(data, opts)
+	case OutputFormatCSV:
+		return renderDelimited(data, opts, ',')
+	case OutputFormatTSV:
+		return renderDelimited(data, opts, '\t')
+	case OutputFormatTemplate:
+		return renderTemplate(data, opts)
+	default:
+		return "", fmt.Errorf("render: unsupported output format %q", format)
+	}
+}
+
+func sectionRows(data *AuditData, section string) (any, error) {
+	section = sectionOrDefault(section)
+	sections := auditSections(data)
+	rows, ok := sections[section]
+	if !ok {
+		return nil, fmt.Errorf("render: unknown section %q", section)
+	}
+	return rows, nil
+}
+
+// sectionOrDefault applies sectionRows' "jobs" default without requiring a
+// full AuditData to resolve against.
+func sectionOrDefault(section string) string {
+	if section == "" {
+		return "jobs"
+	}
+	return section
+}
+
+// filteredSectionRows re-parses a jq-filtered JSON string as a slice of the
+// same element type as section (e.g. []AuditJob for "jobs"), so a filter
+// like ".jobs" composes with table/csv/tsv the same way the unfiltered
+// section does.
+func filteredSectionRows(filtered string, data *AuditData, section string) (any, error) {
+	rows, err := sectionRows(data, section)
+	if err != nil {
+		return nil, err
+	}
+
+	out := reflect.New(reflect.TypeOf(rows))
+	if err := json.Unmarshal([]byte(filtered), out.Interface()); err != nil {
+		return nil, err
+	}
+	return out.Elem().Interface(), nil
+}
+
+// tableColumns reflects over a slice of structs, returning every exported
+// field's table tag (falling back to its Go name) in declaration order,
+// filtered to `only` when non-empty.
+func tableColumns(rows any, only []string) ([]string, error) {
+	v := reflect.ValueOf(rows)
+	elemType := v.Type().Elem()
+
+	var all []string
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		name := field.Tag.Get("table")
+		if name == "" {
+			name = field.Name
+		}
+		all = append(all, name)
+	}
+
+	if len(only) == 0 {
+		return all, nil
+	}
+
+	wanted := make(map[string]bool, len(only))
+	for _, c := range only {
+		wanted[c] = true
+	}
+	var cols []string
+	for _, c := range all {
+		if wanted[c] {
+			cols = append(cols, c)
+		}
+	}
+	for _, c := range only {
+		if !contains(all, c) {
+			return nil, fmt.Errorf("render: unknown column %q", c)
+		}
+	}
+	return cols, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// rowValues returns the string value of each named column for one struct
+// element, resolved via its `table` tag or field name.
+func rowValues(elem reflect.Value, columns []string) []string {
+	elemType := elem.Type()
+	values := make([]string, len(columns))
+
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		name := field.Tag.Get("table")
+		if name == "" {
+			name = field.Name
+		}
+		for ci, col := range columns {
+			if col == name {
+				values[ci] = fmt.Sprintf("%v", elem.Field(i).Interface())
+			}
+		}
+	}
+	return values
+}
+
+func renderTable(data *AuditData, opts RenderOpts) (string, error) {
+	rows, err := sectionRows(data, opts.Section)
+	if err != nil {
+		return "", err
+	}
+	return renderRowsTable(rows, OutputFormatTable, opts.Columns)
+}
+
+func renderDelimited(data *AuditData, opts RenderOpts, delim rune) (string, error) {
+	rows, err := sectionRows(data, opts.Section)
+	if err != nil {
+		return "", err
+	}
+	format := OutputFormatCSV
+	if delim == '\t' {
+		format = OutputFormatTSV
+	}
+	return renderRowsTable(rows, format, opts.Columns)
+}
+
+// renderRowsTable renders a slice of structs (rows) as a table, csv, or tsv,
+// shared by both RenderAudit's AuditData sections and RenderDiff's
+// DiffEntry rows.
+func renderRowsTable(rows any, format OutputFormat, columns []string) (string, error) {
+	columns, err := tableColumns(rows, columns)
+	if err != nil {
+		return "", err
+	}
+
+	v := reflect.ValueOf(rows)
+
+	switch format {
+	case OutputFormatCSV, OutputFormatTSV:
+		delim := ','
+		if format == OutputFormatTSV {
+			delim = '\t'
+		}
+
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Comma = delim
+
+		if err := w.Write(columns); err != nil {
+			return "", err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.Write(rowValues(v.Index(i), columns)); err != nil {
+				return "", err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+
+	default:
+		if v.Len() == 0 {
+			return "(no rows)\n", nil
+		}
+
+		widths := make([]int, len(columns))
+		for i, c := range columns {
+			widths[i] = len(c)
+		}
+
+		rendered := make([][]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values := rowValues(v.Index(i), columns)
+			rendered[i] = values
+			for ci, val := range values {
+				if len(val) > widths[ci] {
+					widths[ci] = len(val)
+				}
+			}
+		}
+
+		var buf bytes.Buffer
+		writeRow := func(cells []string) {
+			for i, cell := range cells {
+				if i > 0 {
+					buf.WriteString("  ")
+				}
+				buf.WriteString(cell)
+				buf.WriteString(strings.Repeat(" ", widths[i]-len(cell)))
+			}
+			buf.WriteByte('\n')
+		}
+
+		writeRow(columns)
+		for _, values := range rendered {
+			writeRow(values)
+		}
+
+		return buf.String(), nil
+	}
+}
+
+// templateFuncs are the helpers available to OutputFormatTemplate sources.
+var templateFuncs = template.FuncMap{
+	"humanBytes": humanBytes,
+	"truncate":   truncateString,
+	"color":      colorString,
+}
+
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func truncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max <= 1 {
+		return s[:max]
+	}
+	return s[:max-1] + "…"
+}
+
+var colorCodes = map[string]string{
+	"red":    "31",
+	"green":  "32",
+	"yellow": "33",
+	"blue":   "34",
+}
+
+func colorString(name, s string) string {
+	code, ok := colorCodes[name]
+	if !ok {
+		return s
+	}
+	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", code, s)
+}
+
+func renderTemplate(data *AuditData, opts RenderOpts) (string, error) {
+	return renderTemplateValue(data, opts)
+}
+
+// renderTemplateValue executes opts.Template against any audit value
+// (AuditData or AuditDiff), so both RenderAudit and RenderDiff can share the
+// same template helpers.
+func renderTemplateValue(data any, opts RenderOpts) (string, error) {
+	if opts.Template == "" {
+		return "", fmt.Errorf("render: template format requires a template string")
+	}
+
+	tmpl, err := template.New("audit").Funcs(templateFuncs).Parse(opts.Template)
+	if err != nil {
+		return "", fmt.Errorf("render: failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render: failed to execute template: %w", err)
+	}
+	return buf.String(), nil
+}
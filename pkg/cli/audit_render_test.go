@@ -0,0 +1,195 @@
+//go:build !integration
+
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func realWorldAuditFixture() *AuditData {
+	return &AuditData{
+		Overview: AuditOverview{
+			RunID:        21784234145,
+			WorkflowName: "Test Workflow",
+			Status:       "completed",
+			Conclusion:   "success",
+			Duration:     "14m0s",
+		},
+		Metrics: AuditMetrics{
+			TokenUsage:    15234,
+			EstimatedCost: "$0.23",
+			Turns:         8,
+			WarningCount:  3,
+		},
+		Jobs: []AuditJob{
+			{Name: "agent", Status: "completed", Conclusion: "success", Duration: "12m30s"},
+		},
+		DownloadedFiles: []DownloadedFile{
+			{Path: "aw_info.json", Size: 1024, SizeFormatted: "1.0 KB", Description: "Workflow configuration"},
+		},
+		MissingTools: []MissingTool{},
+		Warnings: []AuditWarning{
+			{File: "workflow.md", Line: 10, Type: "deprecation", Message: "Using deprecated syntax"},
+		},
+		ToolUsage: []ToolUsage{
+			{Name: "bash", CallCount: 15, MaxInputSize: 256, MaxOutputSize: 1024, MaxDuration: "2.5s"},
+		},
+	}
+}
+
+func TestRenderAudit_Table(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTable, RenderOpts{Section: "jobs"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+
+	const want = "name   status     conclusion  duration\n" +
+		"agent  completed  success     12m30s  \n"
+	if out != want {
+		t.Errorf("table mismatch:\ngot:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestRenderAudit_TableColumnSelection(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTable, RenderOpts{Section: "tool_usage", Columns: []string{"name", "call_count"}})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if !strings.Contains(out, "name  call_count") {
+		t.Errorf("expected header with selected columns, got %q", out)
+	}
+	if strings.Contains(out, "max_input_size") {
+		t.Errorf("expected unselected column to be omitted, got %q", out)
+	}
+}
+
+func TestRenderAudit_TableEmptySection(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTable, RenderOpts{Section: "missing_tools"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if out != "(no rows)\n" {
+		t.Errorf("expected empty-section placeholder, got %q", out)
+	}
+}
+
+func TestRenderAudit_CSV(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatCSV, RenderOpts{Section: "warnings"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	const want = "file,line,type,message\nworkflow.md,10,deprecation,Using deprecated syntax\n"
+	if out != want {
+		t.Errorf("csv mismatch:\ngot:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestRenderAudit_TSV(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTSV, RenderOpts{Section: "jobs"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if !strings.Contains(out, "name\tstatus\tconclusion\tduration") {
+		t.Errorf("expected tab-separated header, got %q", out)
+	}
+}
+
+func TestRenderAudit_Template(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTemplate, RenderOpts{
+		Template: "{{ .Overview.WorkflowName }}: {{ .Metrics.TokenUsage }} tokens ({{ truncate .Overview.Conclusion 4 }})",
+	})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if out != "Test Workflow: 15234 tokens (suc…)" {
+		t.Errorf("unexpected template output: %q", out)
+	}
+}
+
+func TestRenderAudit_TemplateRequiresSource(t *testing.T) {
+	data := realWorldAuditFixture()
+	if _, err := RenderAudit(data, OutputFormatTemplate, RenderOpts{}); err == nil {
+		t.Fatal("expected error when template source is missing")
+	}
+}
+
+func TestRenderAudit_JqThenRender(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatJQ, RenderOpts{JqFilter: ".metrics.token_usage"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if strings.TrimSpace(out) != "15234" {
+		t.Errorf("expected jq-filtered output, got %q", out)
+	}
+}
+
+func TestRenderAudit_JqThenTable(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	out, err := RenderAudit(data, OutputFormatTable, RenderOpts{JqFilter: ".jobs", Section: "jobs"})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if !strings.Contains(out, "agent") {
+		t.Errorf("expected filtered jobs row in table output, got %q", out)
+	}
+}
+
+func TestRenderAudit_JqThenTemplate(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	// The jq-filtered value is arbitrary JSON (snake_case field names), not
+	// the typed *AuditData the unfiltered path hands to templates, so field
+	// access goes through the JSON key rather than the Go struct field name.
+	out, err := RenderAudit(data, OutputFormatTemplate, RenderOpts{
+		JqFilter: ".metrics.token_usage",
+		Template: "{{ . }} tokens",
+	})
+	if err != nil {
+		t.Fatalf("RenderAudit() error = %v", err)
+	}
+	if out != "15234 tokens" {
+		t.Errorf("unexpected template output: %q", out)
+	}
+}
+
+func TestRenderAudit_JqRejectsMismatchedTabularShape(t *testing.T) {
+	data := realWorldAuditFixture()
+
+	for _, format := range []OutputFormat{OutputFormatTable, OutputFormatCSV, OutputFormatTSV} {
+		if _, err := RenderAudit(data, format, RenderOpts{JqFilter: ".metrics", Section: "jobs"}); err == nil {
+			t.Errorf("expected error rendering non-row-shaped --jq result as --output %s", format)
+		}
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{500, "500 B"},
+		{1536, "1.5 KiB"},
+		{1048576, "1.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := humanBytes(tt.n); got != tt.want {
+			t.Errorf("humanBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
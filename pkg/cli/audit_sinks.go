@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SinkFrequency controls how often a sink's filter is evaluated and
+// dispatched. "immediate" fires once per AuditRun; "batch:<duration>" is
+// reserved for callers that want to accumulate payloads before shipping
+// them, e.g. a long-running audit daemon.
+type SinkFrequency string
+
+const (
+	SinkFrequencyImmediate = SinkFrequency("immediate")
+)
+
+// AuditSink is a single declarative filter+destination pair, analogous to a
+// Cloudflare Logpush job's filter and destination_conf: audit results are
+// evaluated against filter, and if the result is truthy/non-empty it is
+// shipped to destination.
+type AuditSink struct {
+	Name        string        `yaml:"name" json:"name"`
+	Filter      string        `yaml:"filter" json:"filter"`
+	Destination string        `yaml:"destination" json:"destination"`
+	Frequency   SinkFrequency `yaml:"frequency" json:"frequency"`
+}
+
+// BatchInterval parses a "batch:<duration>" frequency, returning ok=false
+// for "immediate" or an unparseable value.
+func (s AuditSink) BatchInterval() (d time.Duration, ok bool) {
+	rest, found := strings.CutPrefix(string(s.Frequency), "batch:")
+	if !found {
+		return 0, false
+	}
+	d, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// SinkDispatcher ships a filtered audit payload to a named destination.
+type SinkDispatcher interface {
+	Dispatch(ctx context.Context, name string, payload []byte) error
+}
+
+// sinkRetryPolicy bounds the exponential backoff applied around a
+// dispatcher's Dispatch call.
+type sinkRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	sleep       func(d time.Duration)
+}
+
+func defaultRetryPolicy() sinkRetryPolicy {
+	return sinkRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		sleep:       time.Sleep,
+	}
+}
+
+func (p sinkRetryPolicy) run(fn func() error) error {
+	var lastErr error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			p.sleep(p.BaseDelay * time.Duration(1<<uint(attempt-1)))
+		}
+		if err := fn(); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// FileSinkDispatcher writes payloads to local files rooted at a base
+// directory, for destinations of the form "file://relative/path.json".
+type FileSinkDispatcher struct {
+	BaseDir string
+}
+
+func (d FileSinkDispatcher) Dispatch(ctx context.Context, destination string, payload []byte) error {
+	path := strings.TrimPrefix(destination, "file://")
+	if d.BaseDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(d.BaseDir, path)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("sink: failed to create directory %s: %w", dir, err)
+		}
+	}
+	return os.WriteFile(path, payload, 0o644)
+}
+
+// HTTPSinkDispatcher POSTs payloads to "https://" destinations.
+type HTTPSinkDispatcher struct {
+	Client *http.Client
+}
+
+func (d HTTPSinkDispatcher) Dispatch(ctx context.Context, destination string, payload []byte) error {
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("sink: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sink: request to %s failed: %w", destination, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink: %s responded with status %d", destination, resp.StatusCode)
+	}
+	return nil
+}
+
+// StdoutSinkDispatcher writes payloads to an arbitrary writer, used for the
+// "stdout" destination.
+type StdoutSinkDispatcher struct {
+	Writer interface {
+		Write(p []byte) (n int, err error)
+	}
+}
+
+func (d StdoutSinkDispatcher) Dispatch(ctx context.Context, destination string, payload []byte) error {
+	w := d.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	_, err := w.Write(append(payload, '\n'))
+	return err
+}
+
+// unimplementedSinkDispatcher is returned for destination schemes that are
+// accepted by config validation so workflow authors can write them ahead of
+// time, but have no built-in dispatcher yet. Dispatching to one always
+// fails; the failure only surfaces when a matching sink actually fires.
+type unimplementedSinkDispatcher struct {
+	scheme string
+}
+
+func (d unimplementedSinkDispatcher) Dispatch(ctx context.Context, destination string, payload []byte) error {
+	return fmt.Errorf("sink: destination scheme %q is not yet implemented", d.scheme)
+}
+
+// dispatcherFor resolves the dispatcher responsible for a destination URI
+// scheme. "s3://" and "slack://" destinations are accepted by config
+// validation but not yet implemented by a built-in dispatcher: dispatching
+// to one fails only when a sink matching its filter actually fires.
+func dispatcherFor(destination string, baseDir string) (SinkDispatcher, error) {
+	switch {
+	case destination == "stdout":
+		return StdoutSinkDispatcher{}, nil
+	case strings.HasPrefix(destination, "file://"):
+		return FileSinkDispatcher{BaseDir: baseDir}, nil
+	case strings.HasPrefix(destination, "https://"), strings.HasPrefix(destination, "http://"):
+		return HTTPSinkDispatcher{}, nil
+	case strings.HasPrefix(destination, "s3://"):
+		return unimplementedSinkDispatcher{scheme: "s3://"}, nil
+	case strings.HasPrefix(destination, "slack://"):
+		return unimplementedSinkDispatcher{scheme: "slack://"}, nil
+	default:
+		return nil, fmt.Errorf("sink: unsupported destination %q", destination)
+	}
+}
+
+// RouteToSinks evaluates each sink's filter against data and dispatches the
+// filtered payload to its destination when the filter yields a
+// truthy/non-empty result. It returns every dispatch error encountered,
+// rather than stopping at the first failing sink, so one misconfigured sink
+// doesn't prevent the others from firing.
+func RouteToSinks(ctx context.Context, data *AuditData, sinks []AuditSink, baseDir string) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("sink: failed to marshal audit data: %w", err)
+	}
+
+	var errs []string
+	for _, sink := range sinks {
+		payload, fire, err := evaluateSinkFilter(string(raw), sink.Filter)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", sink.Name, err))
+			continue
+		}
+		if !fire {
+			continue
+		}
+
+		dispatcher, err := dispatcherFor(sink.Destination, baseDir)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", sink.Name, err))
+			continue
+		}
+
+		err = defaultRetryPolicy().run(func() error {
+			return dispatcher.Dispatch(ctx, sink.Destination, payload)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", sink.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("sink: %d dispatch error(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// evaluateSinkFilter runs filter against jsonInput and reports whether the
+// result is truthy/non-empty: jq false/null, empty string, empty array and
+// empty object all suppress dispatch.
+func evaluateSinkFilter(jsonInput, filter string) (payload []byte, fire bool, err error) {
+	output, err := ApplyJqFilter(jsonInput, filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	trimmed := strings.TrimSpace(output)
+	if trimmed == "" {
+		return nil, false, nil
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		// Multiple result lines (non-scalar jq stream): treat as truthy.
+		return []byte(trimmed), true, nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, false, nil
+	case bool:
+		return []byte(trimmed), v, nil
+	case string:
+		return []byte(trimmed), v != "", nil
+	case []any:
+		return []byte(trimmed), len(v) > 0, nil
+	case map[string]any:
+		return []byte(trimmed), len(v) > 0, nil
+	default:
+		return []byte(trimmed), true, nil
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// auditConfig is the `audit:` block of a workflow's YAML frontmatter.
+type auditConfig struct {
+	Sinks []AuditSink `yaml:"sinks"`
+}
+
+// ParseAuditSinks extracts the `audit.sinks` block from workflow YAML
+// frontmatter, validating that each sink has a name, a non-empty filter, a
+// recognized destination scheme, and a well-formed frequency.
+func ParseAuditSinks(yamlContent []byte) ([]AuditSink, error) {
+	var doc struct {
+		Audit auditConfig `yaml:"audit"`
+	}
+	if err := yaml.Unmarshal(yamlContent, &doc); err != nil {
+		return nil, fmt.Errorf("audit: failed to parse audit config: %w", err)
+	}
+
+	for i, sink := range doc.Audit.Sinks {
+		if sink.Frequency == "" {
+			doc.Audit.Sinks[i].Frequency = SinkFrequencyImmediate
+		}
+	}
+
+	for _, sink := range doc.Audit.Sinks {
+		if err := validateAuditSink(sink); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Audit.Sinks, nil
+}
+
+func validateAuditSink(sink AuditSink) error {
+	if sink.Name == "" {
+		return fmt.Errorf("audit: sink is missing a name")
+	}
+	if strings.TrimSpace(sink.Filter) == "" {
+		return fmt.Errorf("audit: sink %q is missing a filter", sink.Name)
+	}
+	if _, err := dispatcherFor(sink.Destination, ""); err != nil {
+		return fmt.Errorf("audit: sink %q: %w", sink.Name, err)
+	}
+	if sink.Frequency != SinkFrequencyImmediate {
+		if _, ok := sink.BatchInterval(); !ok {
+			return fmt.Errorf("audit: sink %q has invalid frequency %q, expected \"immediate\" or \"batch:<duration>\"", sink.Name, sink.Frequency)
+		}
+	}
+	return nil
+}
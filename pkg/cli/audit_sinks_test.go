@@ -0,0 +1,193 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func fixtureAuditData(warnings int) *AuditData {
+	data := &AuditData{
+		Overview: AuditOverview{RunID: 123, WorkflowName: "test-workflow", Conclusion: "success"},
+		Metrics:  AuditMetrics{TokenUsage: 1000},
+	}
+	for i := 0; i < warnings; i++ {
+		data.Warnings = append(data.Warnings, AuditWarning{Message: "deprecated syntax"})
+	}
+	return data
+}
+
+func TestRouteToSinks_FiltersOnContent(t *testing.T) {
+	t.Run("fires when warnings present", func(t *testing.T) {
+		dir := t.TempDir()
+		sinks := []AuditSink{
+			{Name: "warnings-only", Filter: ".warnings | select(length > 0)", Destination: "file://out.json", Frequency: SinkFrequencyImmediate},
+		}
+
+		if err := RouteToSinks(context.Background(), fixtureAuditData(2), sinks, dir); err != nil {
+			t.Fatalf("RouteToSinks() error = %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "out.json")); err != nil {
+			t.Errorf("expected sink output file to exist: %v", err)
+		}
+	})
+
+	t.Run("does not fire when no warnings", func(t *testing.T) {
+		dir := t.TempDir()
+		sinks := []AuditSink{
+			{Name: "warnings-only", Filter: ".warnings | select(length > 0)", Destination: "file://out.json", Frequency: SinkFrequencyImmediate},
+		}
+
+		if err := RouteToSinks(context.Background(), fixtureAuditData(0), sinks, dir); err != nil {
+			t.Fatalf("RouteToSinks() error = %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "out.json")); !os.IsNotExist(err) {
+			t.Errorf("expected sink output file not to be created, err = %v", err)
+		}
+	})
+
+	t.Run("stdout destination", func(t *testing.T) {
+		sinks := []AuditSink{
+			{Name: "all", Filter: ".", Destination: "stdout", Frequency: SinkFrequencyImmediate},
+		}
+		if err := RouteToSinks(context.Background(), fixtureAuditData(0), sinks, ""); err != nil {
+			t.Fatalf("RouteToSinks() error = %v", err)
+		}
+	})
+
+	t.Run("not-yet-implemented destination reports an error but does not abort other sinks", func(t *testing.T) {
+		dir := t.TempDir()
+		sinks := []AuditSink{
+			{Name: "bad", Filter: ".", Destination: "s3://bucket/key", Frequency: SinkFrequencyImmediate},
+			{Name: "good", Filter: ".", Destination: "file://out.json", Frequency: SinkFrequencyImmediate},
+		}
+		err := RouteToSinks(context.Background(), fixtureAuditData(0), sinks, dir)
+		if err == nil {
+			t.Fatal("expected an error for the not-yet-implemented destination")
+		}
+		if _, statErr := os.Stat(filepath.Join(dir, "out.json")); statErr != nil {
+			t.Errorf("expected the good sink to still dispatch: %v", statErr)
+		}
+	})
+
+	t.Run("unsupported destination reports an error but does not abort other sinks", func(t *testing.T) {
+		dir := t.TempDir()
+		sinks := []AuditSink{
+			{Name: "bad", Filter: ".", Destination: "ftp://example.com", Frequency: SinkFrequencyImmediate},
+			{Name: "good", Filter: ".", Destination: "file://out.json", Frequency: SinkFrequencyImmediate},
+		}
+		err := RouteToSinks(context.Background(), fixtureAuditData(0), sinks, dir)
+		if err == nil {
+			t.Fatal("expected an error for the unsupported destination")
+		}
+		if _, statErr := os.Stat(filepath.Join(dir, "out.json")); statErr != nil {
+			t.Errorf("expected the good sink to still dispatch: %v", statErr)
+		}
+	})
+}
+
+func TestParseAuditSinks(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		yamlContent := []byte(`
+audit:
+  sinks:
+    - name: warnings
+      filter: ".warnings | select(length > 0)"
+      destination: "file://warnings.json"
+      frequency: immediate
+    - name: hourly
+      filter: "."
+      destination: "stdout"
+      frequency: "batch:1h"
+`)
+		sinks, err := ParseAuditSinks(yamlContent)
+		if err != nil {
+			t.Fatalf("ParseAuditSinks() error = %v", err)
+		}
+		if len(sinks) != 2 {
+			t.Fatalf("expected 2 sinks, got %d", len(sinks))
+		}
+		if d, ok := sinks[1].BatchInterval(); !ok || d.String() != "1h0m0s" {
+			t.Errorf("expected batch interval 1h0m0s, got %v ok=%v", d, ok)
+		}
+	})
+
+	t.Run("defaults frequency to immediate", func(t *testing.T) {
+		sinks, err := ParseAuditSinks([]byte(`
+audit:
+  sinks:
+    - name: all
+      filter: "."
+      destination: stdout
+`))
+		if err != nil {
+			t.Fatalf("ParseAuditSinks() error = %v", err)
+		}
+		if sinks[0].Frequency != SinkFrequencyImmediate {
+			t.Errorf("expected default frequency %q, got %q", SinkFrequencyImmediate, sinks[0].Frequency)
+		}
+	})
+
+	t.Run("rejects missing filter", func(t *testing.T) {
+		_, err := ParseAuditSinks([]byte(`
+audit:
+  sinks:
+    - name: bad
+      destination: stdout
+`))
+		if err == nil {
+			t.Fatal("expected an error for missing filter")
+		}
+	})
+
+	t.Run("accepts not-yet-implemented destination schemes", func(t *testing.T) {
+		sinks, err := ParseAuditSinks([]byte(`
+audit:
+  sinks:
+    - name: s3-sink
+      filter: "."
+      destination: "s3://bucket/key"
+    - name: slack-sink
+      filter: "."
+      destination: "slack://#channel"
+`))
+		if err != nil {
+			t.Fatalf("ParseAuditSinks() error = %v", err)
+		}
+		if len(sinks) != 2 {
+			t.Fatalf("expected 2 sinks, got %d", len(sinks))
+		}
+	})
+
+	t.Run("rejects unsupported destination", func(t *testing.T) {
+		_, err := ParseAuditSinks([]byte(`
+audit:
+  sinks:
+    - name: bad
+      filter: "."
+      destination: "ftp://example.com"
+`))
+		if err == nil {
+			t.Fatal("expected an error for unsupported destination")
+		}
+	})
+
+	t.Run("rejects malformed frequency", func(t *testing.T) {
+		_, err := ParseAuditSinks([]byte(`
+audit:
+  sinks:
+    - name: bad
+      filter: "."
+      destination: stdout
+      frequency: "whenever"
+`))
+		if err == nil {
+			t.Fatal("expected an error for malformed frequency")
+		}
+	})
+}
@@ -0,0 +1,73 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCachedAudit(t *testing.T, logsDir string, runID int64, data AuditData) {
+	t.Helper()
+	dir := runDir(logsDir, runID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create run dir: %v", err)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "audit.json"), raw, 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+}
+
+func TestAuditRun(t *testing.T) {
+	t.Run("missing run id", func(t *testing.T) {
+		_, err := AuditRun(context.Background(), AuditOptions{LogsDir: t.TempDir()})
+		if err == nil {
+			t.Fatal("expected error for missing run id")
+		}
+	})
+
+	t.Run("no cached logs", func(t *testing.T) {
+		_, err := AuditRun(context.Background(), AuditOptions{RunID: 123, LogsDir: t.TempDir()})
+		if err == nil {
+			t.Fatal("expected error when no cached logs exist")
+		}
+	})
+
+	t.Run("reads cached audit data", func(t *testing.T) {
+		logsDir := t.TempDir()
+		writeCachedAudit(t, logsDir, 21784234145, AuditData{
+			Overview: AuditOverview{WorkflowName: "Test Workflow", Conclusion: "success"},
+			Metrics:  AuditMetrics{TokenUsage: 15234},
+		})
+
+		data, err := AuditRun(context.Background(), AuditOptions{RunID: 21784234145, LogsDir: logsDir})
+		if err != nil {
+			t.Fatalf("AuditRun() error = %v", err)
+		}
+		if data.Overview.RunID != 21784234145 {
+			t.Errorf("expected run id to be backfilled, got %d", data.Overview.RunID)
+		}
+		if data.Overview.WorkflowName != "Test Workflow" {
+			t.Errorf("expected workflow name to be preserved, got %q", data.Overview.WorkflowName)
+		}
+		if data.Metrics.TokenUsage != 15234 {
+			t.Errorf("expected token usage 15234, got %d", data.Metrics.TokenUsage)
+		}
+	})
+
+	t.Run("respects cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := AuditRun(ctx, AuditOptions{RunID: 1, LogsDir: t.TempDir()})
+		if err == nil {
+			t.Fatal("expected error for cancelled context")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAuditDiffCommand creates the `gh aw audit diff` command for comparing
+// two previously-audited runs.
+func NewAuditDiffCommand() *cobra.Command {
+	var arrayKeyFlags []string
+	var thresholdFlags []string
+	var jqFilter string
+	var output string
+	var logsDir string
+	var tmplSource string
+
+	cmd := &cobra.Command{
+		Use:   "diff <run-a> <run-b>",
+		Short: "Compare two workflow runs' audit data",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runA, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid run id %q: %w", args[0], err)
+			}
+			runB, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid run id %q: %w", args[1], err)
+			}
+
+			dataA, err := AuditRun(cmd.Context(), AuditOptions{RunID: runA, LogsDir: logsDir})
+			if err != nil {
+				return err
+			}
+			dataB, err := AuditRun(cmd.Context(), AuditOptions{RunID: runB, LogsDir: logsDir})
+			if err != nil {
+				return err
+			}
+
+			var arrayKeys []ArrayKey
+			for _, flag := range arrayKeyFlags {
+				ak, err := ParseArrayKeyFlag(flag)
+				if err != nil {
+					return err
+				}
+				arrayKeys = append(arrayKeys, ak)
+			}
+
+			var thresholds []Threshold
+			for _, flag := range thresholdFlags {
+				th, err := ParseThresholdFlag(flag)
+				if err != nil {
+					return err
+				}
+				thresholds = append(thresholds, th)
+			}
+
+			diff, err := DiffAudits(dataA, dataB, DiffOpts{ArrayKeys: arrayKeys, Thresholds: thresholds})
+			if err != nil {
+				return err
+			}
+
+			format := OutputFormat(output)
+			if format == "" {
+				format = OutputFormatJSON
+			}
+			if jqFilter != "" && output == "" {
+				format = OutputFormatJQ
+			}
+
+			rendered, err := RenderDiff(diff, format, RenderOpts{JqFilter: jqFilter, Template: tmplSource})
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), rendered)
+
+			if breached := diff.CheckThresholds(thresholds); len(breached) > 0 {
+				var lines []string
+				for _, entry := range breached {
+					lines = append(lines, fmt.Sprintf("%s regressed %.2f%%", entry.Path, entry.Pct))
+				}
+				return fmt.Errorf("threshold breached: %s", strings.Join(lines, "; "))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&arrayKeyFlags, "array-key", nil, `set-diff an array path by a key field, e.g. ".jobs=name"`)
+	cmd.Flags().StringArrayVar(&thresholdFlags, "threshold", nil, `exit non-zero if a numeric field regresses beyond a bound, e.g. ".metrics.token_usage=10%"`)
+	cmd.Flags().StringVar(&jqFilter, "jq", "", "filter the diff output with a jq expression")
+	cmd.Flags().StringVar(&output, "output", "", "output format: json, jq, table, csv, tsv, template (default json)")
+	cmd.Flags().StringVar(&logsDir, "logs-dir", ".github/aw/logs", "directory containing cached run logs")
+	cmd.Flags().StringVar(&tmplSource, "template", "", "Go text/template source, used with --output template")
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewJobCommand creates the `gh aw job` command group for inspecting and
+// controlling background audit jobs started with `gh aw audit --async`.
+func NewJobCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "job",
+		Short: "Manage background audit jobs",
+	}
+
+	cmd.AddCommand(newJobStatusCommand())
+	cmd.AddCommand(newJobListCommand())
+	cmd.AddCommand(newJobStopCommand())
+
+	return cmd
+}
+
+func newJobStatusCommand() *cobra.Command {
+	var jqFilter string
+
+	cmd := &cobra.Command{
+		Use:   "status <job-id>",
+		Short: "Show the status of a background audit job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			job, err := defaultJobRegistry.Status(args[0])
+			if err != nil {
+				return err
+			}
+
+			if jqFilter != "" {
+				// Filter against the stored result directly (e.g. .metrics.token_usage),
+				// as documented by the flag help, falling back to the job wrapper
+				// while the job hasn't produced a result yet.
+				target := any(job)
+				if job.Result != nil {
+					target = job.Result
+				}
+				raw, err := json.MarshalIndent(target, "", "  ")
+				if err != nil {
+					return err
+				}
+				filtered, err := ApplyJqFilter(string(raw), jqFilter)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(cmd.OutOrStdout(), filtered)
+				return nil
+			}
+
+			raw, err := json.MarshalIndent(job, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&jqFilter, "jq", "", "filter the job's stored result with a jq expression, e.g. .metrics.token_usage")
+
+	return cmd
+}
+
+func newJobListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List background audit jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobs := defaultJobRegistry.List()
+			raw, err := json.MarshalIndent(jobs, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+			return nil
+		},
+	}
+}
+
+func newJobStopCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop <job-id>",
+		Short: "Cancel a running background audit job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return defaultJobRegistry.Stop(args[0])
+		},
+	}
+}
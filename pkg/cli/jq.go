@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/itchyny/gojq"
+)
+
+// jqCodeCache memoizes compiled gojq programs keyed by filter string, since
+// audit commands frequently apply the same filter expression across many
+// runs (e.g. job status polling, sink dispatch) and re-parsing/compiling on
+// every call would be wasted work.
+var (
+	jqCodeCacheMu sync.Mutex
+	jqCodeCache   = make(map[string]*gojq.Code)
+)
+
+// ApplyJqFilter evaluates a jq filter expression against jsonInput using an
+// embedded pure-Go jq engine (gojq), so audit commands no longer depend on a
+// system "jq" binary being present in PATH. The result stream is marshaled
+// back to JSON, one value per line, matching the output of the external jq
+// CLI that this replaces.
+func ApplyJqFilter(jsonInput, filter string) (string, error) {
+	if strings.TrimSpace(filter) == "" {
+		return "", fmt.Errorf("jq filter cannot be empty")
+	}
+
+	code, err := compiledJqFilter(filter)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse jq filter: %w", err)
+	}
+
+	var input any
+	dec := json.NewDecoder(strings.NewReader(jsonInput))
+	dec.UseNumber()
+	if err := dec.Decode(&input); err != nil {
+		return "", fmt.Errorf("failed to parse input JSON: %w", err)
+	}
+
+	var out bytes.Buffer
+	iter := code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return "", fmt.Errorf("jq filter execution failed: %w", err)
+		}
+
+		encoded, err := marshalJqResult(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal jq result: %w", err)
+		}
+		out.Write(encoded)
+		out.WriteByte('\n')
+	}
+
+	return out.String(), nil
+}
+
+// compiledJqFilter parses and compiles filter, reusing a cached program when
+// the same expression has been seen before.
+func compiledJqFilter(filter string) (*gojq.Code, error) {
+	jqCodeCacheMu.Lock()
+	code, ok := jqCodeCache[filter]
+	jqCodeCacheMu.Unlock()
+	if ok {
+		return code, nil
+	}
+
+	query, err := gojq.Parse(filter)
+	if err != nil {
+		return nil, err
+	}
+	code, err = gojq.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+
+	jqCodeCacheMu.Lock()
+	jqCodeCache[filter] = code
+	jqCodeCacheMu.Unlock()
+
+	return code, nil
+}
+
+// marshalJqResult renders a single jq result value, pretty-printing
+// arrays/objects to match the formatting of the external jq CLI.
+func marshalJqResult(v any) ([]byte, error) {
+	switch v.(type) {
+	case map[string]any, []any:
+		return json.MarshalIndent(v, "", "  ")
+	default:
+		return json.Marshal(v)
+	}
+}
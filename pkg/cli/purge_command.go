@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// NewPurgeCommand creates the `gh aw purge` command group for retention and
+// cleanup of cached run logs.
+func NewPurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Purge old cached audit logs",
+	}
+
+	cmd.AddCommand(newPurgeRunCommand())
+	cmd.AddCommand(newPurgeHistoryCommand())
+	cmd.AddCommand(newPurgeScheduleCommand())
+
+	return cmd
+}
+
+func newPurgeRunCommand() *cobra.Command {
+	var retainDays int
+	var retainCount int
+	var include []string
+	var exclude []string
+	var dryRun bool
+	var logsDir string
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Delete cached run logs outside the retention policy",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policy := PurgePolicy{
+				RetainDays:  retainDays,
+				RetainCount: retainCount,
+				Include:     include,
+				Exclude:     exclude,
+				DryRun:      dryRun,
+			}
+
+			report, err := RunPurge(cmd.Context(), logsDir, policy, defaultJobRegistry)
+			if err != nil {
+				return err
+			}
+			if !dryRun {
+				if histErr := AppendPurgeHistory(defaultPurgeHistoryPath, report); histErr != nil {
+					return histErr
+				}
+			}
+
+			raw, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&retainDays, "retain-days", 30, "always keep runs modified within this many days")
+	cmd.Flags().IntVar(&retainCount, "retain-count", 20, "always keep this many of the most recently modified runs")
+	cmd.Flags().StringSliceVar(&include, "include", nil, "only purge runs whose workflow name matches one of these globs")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "never purge runs whose workflow name matches one of these globs")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would be deleted without deleting anything")
+	cmd.Flags().StringVar(&logsDir, "logs-dir", ".github/aw/logs", "directory containing cached run logs")
+
+	return cmd
+}
+
+func newPurgeHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [id]",
+		Short: "Show past purge executions",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			history, err := LoadPurgeHistory(defaultPurgeHistoryPath)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				for _, report := range history {
+					if report.ID == args[0] {
+						raw, err := json.MarshalIndent(report, "", "  ")
+						if err != nil {
+							return err
+						}
+						fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+						return nil
+					}
+				}
+				return fmt.Errorf("purge history entry %q not found", args[0])
+			}
+
+			raw, err := json.MarshalIndent(history, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newPurgeScheduleCommand() *cobra.Command {
+	var cron string
+	var retainDays int
+	var retainCount int
+
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Write a scheduled workflow that runs `gh aw purge run` on a cron",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cron == "" {
+				return fmt.Errorf("purge schedule: --cron is required")
+			}
+
+			workflowPath := filepath.Join(".github", "workflows", "aw-purge.yml")
+			if err := os.MkdirAll(filepath.Dir(workflowPath), 0o755); err != nil {
+				return fmt.Errorf("purge schedule: failed to create workflow directory: %w", err)
+			}
+
+			content := purgeScheduleWorkflow(cron, retainDays, retainCount)
+			if err := os.WriteFile(workflowPath, []byte(content), 0o644); err != nil {
+				return fmt.Errorf("purge schedule: failed to write %s: %w", workflowPath, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote scheduled purge workflow to %s\n", workflowPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&cron, "cron", "", `cron schedule for the purge, e.g. "0 3 * * *"`)
+	cmd.Flags().IntVar(&retainDays, "retain-days", 30, "retain-days passed to the scheduled `gh aw purge run`")
+	cmd.Flags().IntVar(&retainCount, "retain-count", 20, "retain-count passed to the scheduled `gh aw purge run`")
+
+	return cmd
+}
+
+func purgeScheduleWorkflow(cron string, retainDays, retainCount int) string {
+	return fmt.Sprintf(`# Generated by "gh aw purge schedule". Edit the cron expression or retention
+# flags below and re-run the command, or edit this file directly.
+name: aw-purge
+on:
+  schedule:
+    - cron: %q
+  workflow_dispatch: {}
+jobs:
+  purge:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Purge old audit logs
+        run: gh aw purge run --retain-days %s --retain-count %s
+        env:
+          GH_TOKEN: ${{ github.token }}
+`, cron, strconv.Itoa(retainDays), strconv.Itoa(retainCount))
+}